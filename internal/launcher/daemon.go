@@ -0,0 +1,129 @@
+package launcher
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	winio "github.com/Microsoft/go-winio"
+)
+
+// PipeName is the Windows named pipe the daemon listens on. A globally
+// bound hotkey script or AutoHotkey macro can dial it directly rather than
+// re-scanning the registry on every launch.
+const PipeName = `\\.\pipe\zap`
+
+// Daemon serves a minimal line protocol over PipeName:
+//
+//	LIST            -> tab-separated app names
+//	LAUNCH <query>  -> fuzzy-matches query against app names via Filter and
+//	                   launches the top hit; "OK <name>" or "ERR <reason>".
+//	                   Unlike the `launch` CLI subcommand, there is no
+//	                   --first flag or ambiguity check: an imprecise query
+//	                   silently launches whatever Filter ranks first.
+//	SEARCH <terms>  -> "OK" or "ERR <reason>"
+//	RELOAD          -> "OK <n> apps"
+type Daemon struct {
+	cfg SearchConfig
+
+	mu    sync.RWMutex
+	apps  []App
+	usage UsageCache
+}
+
+// NewDaemon loads the initial app list and usage cache and returns a
+// Daemon ready to serve.
+func NewDaemon(cfg SearchConfig) *Daemon {
+	return &Daemon{
+		cfg:   cfg,
+		apps:  Load(),
+		usage: LoadUsageCache(),
+	}
+}
+
+// ListenAndServe accepts connections on PipeName until it errors (e.g. the
+// listener is closed).
+func (d *Daemon) ListenAndServe() error {
+	l, err := winio.ListenPipe(PipeName, nil)
+	if err != nil {
+		return fmt.Errorf("launcher: listen %s: %w", PipeName, err)
+	}
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go d.handleConn(conn)
+	}
+}
+
+func (d *Daemon) handleConn(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		fmt.Fprintln(conn, d.dispatch(strings.ToUpper(cmd), arg))
+	}
+}
+
+func (d *Daemon) dispatch(cmd, arg string) string {
+	switch cmd {
+	case "LIST":
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+		names := make([]string, len(d.apps))
+		for i, a := range d.apps {
+			names[i] = a.Name
+		}
+		return strings.Join(names, "\t")
+
+	case "LAUNCH":
+		d.mu.RLock()
+		apps := d.apps
+		d.mu.RUnlock()
+
+		matches, _ := Filter(apps, arg)
+		if len(matches) == 0 {
+			return "ERR no match for " + arg
+		}
+		app := matches[0]
+		if err := Launch(app); err != nil {
+			return "ERR " + err.Error()
+		}
+
+		d.mu.Lock()
+		err := d.usage.RecordLaunch(app.Key())
+		d.mu.Unlock()
+		if err != nil {
+			return fmt.Sprintf("OK %s (usage save failed: %v)", app.Name, err)
+		}
+		return "OK " + app.Name
+
+	case "SEARCH":
+		d.mu.RLock()
+		cfg := d.cfg
+		d.mu.RUnlock()
+		if err := Search(arg, cfg); err != nil {
+			return "ERR " + err.Error()
+		}
+		return "OK"
+
+	case "RELOAD":
+		apps := Load()
+		d.mu.Lock()
+		d.apps = apps
+		d.mu.Unlock()
+		return fmt.Sprintf("OK %d apps", len(apps))
+
+	default:
+		return "ERR unknown command " + cmd
+	}
+}
@@ -0,0 +1,155 @@
+// Package launcher discovers installed Windows applications and launches
+// them. It has no TUI or CLI dependencies so it can be driven by a
+// terminal UI, one-shot CLI subcommands, or the pipe daemon alike.
+package launcher
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+	"golang.org/x/sys/windows/registry"
+)
+
+// App is a discovered, launchable application.
+type App struct {
+	Name  string
+	AppID string // shell:AppsFolder ID (from Get-StartApps)
+	Path  string // install location (from registry)
+}
+
+// Key returns the identifier used to track this app in the usage cache:
+// the AppID when present (Start menu apps), else the install location.
+func (a App) Key() string {
+	if a.AppID != "" {
+		return a.AppID
+	}
+	return a.Path
+}
+
+// ghostApps lists display names (lowercase) of apps that have been
+// uninstalled but still appear in Get-StartApps due to Windows caching.
+var ghostApps = map[string]bool{
+	"google chrome": true,
+}
+
+// Load collects apps from two sources:
+//  1. Start menu entries via Get-StartApps (PowerShell)
+//  2. Registry uninstall entries with an InstallLocation
+//
+// Ghost entries are excluded. Results are sorted alphabetically.
+func Load() []App {
+	seen := make(map[string]bool)
+	var apps []App
+
+	// Start menu apps
+	out, err := exec.Command("powershell", "-NoProfile", "-Command",
+		`Get-StartApps | ForEach-Object { "$($_.Name)|$($_.AppID)" }`).Output()
+	if err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			parts := strings.SplitN(strings.TrimSpace(line), "|", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				continue
+			}
+			lower := strings.ToLower(parts[0])
+			if seen[lower] || ghostApps[lower] {
+				continue
+			}
+			seen[lower] = true
+			apps = append(apps, App{Name: parts[0], AppID: parts[1]})
+		}
+	}
+
+	// Registry apps (uninstall entries with exe paths)
+	regPaths := []string{
+		`SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`,
+		`SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall`,
+	}
+	for _, rp := range regPaths {
+		k, err := registry.OpenKey(registry.LOCAL_MACHINE, rp, registry.READ)
+		if err != nil {
+			continue
+		}
+		names, _ := k.ReadSubKeyNames(-1)
+		k.Close()
+		for _, name := range names {
+			sub, err := registry.OpenKey(registry.LOCAL_MACHINE, rp+`\`+name, registry.READ)
+			if err != nil {
+				continue
+			}
+			displayName, _, _ := sub.GetStringValue("DisplayName")
+			installLoc, _, _ := sub.GetStringValue("InstallLocation")
+			sub.Close()
+			if displayName == "" || installLoc == "" {
+				continue
+			}
+			lower := strings.ToLower(displayName)
+			if seen[lower] {
+				continue
+			}
+			seen[lower] = true
+			apps = append(apps, App{Name: displayName, Path: installLoc})
+		}
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return strings.ToLower(apps[i].Name) < strings.ToLower(apps[j].Name)
+	})
+	return apps
+}
+
+// Filter fuzzy-matches query against app names and returns the apps
+// ordered by relevance (best match first), along with the matched rune
+// indices into each returned name for highlighting. An empty query returns
+// all apps alphabetically with no highlights.
+func Filter(apps []App, query string) ([]App, [][]int) {
+	if query == "" {
+		return apps, nil
+	}
+
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = strings.ToLower(app.Name)
+	}
+
+	results := fuzzy.Find(strings.ToLower(query), names)
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return len(names[results[i].Index]) < len(names[results[j].Index])
+	})
+
+	out := make([]App, len(results))
+	matches := make([][]int, len(results))
+	for i, r := range results {
+		out[i] = apps[r.Index]
+		// fuzzy reports byte offsets; convert them to rune indices.
+		runeIdx := make([]int, 0, len(r.MatchedIndexes))
+		ri, mi := 0, 0
+		for b := range names[r.Index] {
+			if mi < len(r.MatchedIndexes) && r.MatchedIndexes[mi] == b {
+				runeIdx = append(runeIdx, ri)
+				mi++
+			}
+			ri++
+		}
+		matches[i] = runeIdx
+	}
+	return out, matches
+}
+
+// Launch starts app via explorer.exe, the same mechanism the Start menu
+// itself uses for both shell:AppsFolder IDs and classic install paths.
+func Launch(app App) error {
+	switch {
+	case app.AppID != "":
+		return exec.Command("explorer.exe", "shell:AppsFolder\\"+app.AppID).Start()
+	case app.Path != "":
+		return exec.Command("explorer.exe", app.Path).Start()
+	default:
+		return fmt.Errorf("launcher: %q has neither an AppID nor a Path", app.Name)
+	}
+}
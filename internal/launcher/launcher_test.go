@@ -0,0 +1,29 @@
+package launcher
+
+import "testing"
+
+func TestFilterTypoTolerance(t *testing.T) {
+	apps := []App{{Name: "Notepad"}, {Name: "Google Chrome"}, {Name: "Visual Studio Code"}}
+	out, _ := Filter(apps, "chrme")
+	if len(out) == 0 || out[0].Name != "Google Chrome" {
+		t.Fatalf("Filter(%q) top result = %+v, want Google Chrome first", "chrme", out)
+	}
+}
+
+func TestFilterAcronymMatch(t *testing.T) {
+	apps := []App{{Name: "Notepad"}, {Name: "Visual Studio Code"}, {Name: "Google Chrome"}}
+	out, _ := Filter(apps, "vsc")
+	if len(out) == 0 || out[0].Name != "Visual Studio Code" {
+		t.Fatalf("Filter(%q) top result = %+v, want Visual Studio Code first", "vsc", out)
+	}
+}
+
+func TestFilterTieBreaksByNameLength(t *testing.T) {
+	// Both names score identically against "app" in github.com/sahilm/fuzzy;
+	// the shorter one must sort first.
+	apps := []App{{Name: "x-app extra"}, {Name: "xy-app"}}
+	out, _ := Filter(apps, "app")
+	if len(out) != 2 || out[0].Name != "xy-app" || out[1].Name != "x-app extra" {
+		t.Fatalf("Filter(%q) = %+v, want [xy-app, x-app extra]", "app", out)
+	}
+}
@@ -0,0 +1,168 @@
+package launcher
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/sys/windows/registry"
+)
+
+// SearchConfig holds the web-search engine templates available from the
+// `/` prefix and which one is used when no engine prefix is given. Engine
+// URLs contain a literal "{query}" placeholder.
+type SearchConfig struct {
+	DefaultEngine string            `toml:"default_engine"`
+	Engines       map[string]string `toml:"engines"`
+}
+
+func defaultSearchConfig() SearchConfig {
+	return SearchConfig{
+		DefaultEngine: "ddg",
+		Engines: map[string]string{
+			"ddg": "https://duckduckgo.com/?q={query}",
+			"g":   "https://www.google.com/search?q={query}",
+			"b":   "https://www.bing.com/search?q={query}",
+			"k":   "https://kagi.com/search?q={query}",
+		},
+	}
+}
+
+// ConfigPath returns %LOCALAPPDATA%\zap\config.toml.
+func ConfigPath() string {
+	return filepath.Join(os.Getenv("LOCALAPPDATA"), "zap", "config.toml")
+}
+
+// LoadSearchConfig reads config.toml over the built-in defaults, so a user
+// file only needs to set what it wants to override.
+func LoadSearchConfig() SearchConfig {
+	cfg := defaultSearchConfig()
+
+	data, err := os.ReadFile(ConfigPath())
+	if err != nil {
+		return cfg
+	}
+	var fileCfg SearchConfig
+	if _, err := toml.Decode(string(data), &fileCfg); err != nil {
+		return cfg
+	}
+	if fileCfg.DefaultEngine != "" {
+		cfg.DefaultEngine = fileCfg.DefaultEngine
+	}
+	for name, u := range fileCfg.Engines {
+		cfg.Engines[name] = u
+	}
+	return cfg
+}
+
+// ResolveSearch splits a "/"-prefixed input into the engine URL template
+// and the search terms. A leading token that names a configured engine
+// (e.g. "/g query" → "g") selects that engine; otherwise the whole
+// remainder is the query and DefaultEngine is used.
+func (cfg SearchConfig) ResolveSearch(raw string) (engineURL, query string) {
+	raw = strings.TrimPrefix(raw, "/")
+	if prefix, rest, ok := strings.Cut(raw, " "); ok {
+		if u, isEngine := cfg.Engines[prefix]; isEngine {
+			return u, strings.TrimSpace(rest)
+		}
+	}
+	return cfg.Engines[cfg.DefaultEngine], strings.TrimSpace(raw)
+}
+
+// Search opens terms in the default browser using cfg's default engine.
+func Search(terms string, cfg SearchConfig) error {
+	engineURL := cfg.Engines[cfg.DefaultEngine]
+	if engineURL == "" {
+		return fmt.Errorf("launcher: no search engine configured for %q", cfg.DefaultEngine)
+	}
+	return OpenURL(strings.Replace(engineURL, "{query}", url.QueryEscape(terms), 1))
+}
+
+// OpenURL starts the user's default browser on rawURL.
+func OpenURL(rawURL string) error {
+	browser := DefaultBrowserPath()
+	if browser == "" {
+		return fmt.Errorf("launcher: could not resolve a default browser")
+	}
+	return exec.Command(browser, rawURL).Start()
+}
+
+// ── Browser Detection ───────────────────────────────────────────
+
+// DefaultBrowserPath resolves the user's default browser executable: first
+// via the HKCU UserChoice ProgId (the same mechanism Windows itself uses),
+// falling back to probing known install locations for common browsers.
+func DefaultBrowserPath() string {
+	if progID, err := readUserChoiceProgID(); err == nil {
+		if exe, err := progIDExecutable(progID); err == nil {
+			return exe
+		}
+	}
+	for _, p := range knownBrowserPaths() {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+func readUserChoiceProgID() (string, error) {
+	k, err := registry.OpenKey(registry.CURRENT_USER,
+		`Software\Microsoft\Windows\Shell\Associations\UrlAssociations\https\UserChoice`, registry.READ)
+	if err != nil {
+		return "", err
+	}
+	defer k.Close()
+	v, _, err := k.GetStringValue("ProgId")
+	return v, err
+}
+
+// progIDExecutable looks up HKCR\<progID>\shell\open\command and extracts
+// the executable path from its (possibly quoted, argument-bearing) value.
+func progIDExecutable(progID string) (string, error) {
+	k, err := registry.OpenKey(registry.CLASSES_ROOT, progID+`\shell\open\command`, registry.READ)
+	if err != nil {
+		return "", err
+	}
+	defer k.Close()
+	cmd, _, err := k.GetStringValue("")
+	if err != nil {
+		return "", err
+	}
+	return extractExecutable(cmd), nil
+}
+
+func extractExecutable(command string) string {
+	command = strings.TrimSpace(command)
+	if strings.HasPrefix(command, `"`) {
+		if end := strings.Index(command[1:], `"`); end >= 0 {
+			return command[1 : end+1]
+		}
+	}
+	if sp := strings.IndexByte(command, ' '); sp >= 0 {
+		return command[:sp]
+	}
+	return command
+}
+
+// knownBrowserPaths lists common install locations to probe when the
+// UserChoice registry lookup fails or points at something unusable.
+func knownBrowserPaths() []string {
+	programFiles := os.Getenv("ProgramFiles")
+	programFilesX86 := os.Getenv("ProgramFiles(x86)")
+	localAppData := os.Getenv("LOCALAPPDATA")
+	return []string{
+		filepath.Join(programFiles, `Google\Chrome\Application\chrome.exe`),
+		filepath.Join(programFilesX86, `Google\Chrome\Application\chrome.exe`),
+		filepath.Join(programFiles, `Microsoft\Edge\Application\msedge.exe`),
+		filepath.Join(programFiles, `Mozilla Firefox\firefox.exe`),
+		filepath.Join(programFilesX86, `Mozilla Firefox\firefox.exe`),
+		filepath.Join(localAppData, `BraveSoftware\Brave-Browser\Application\brave.exe`),
+		filepath.Join(localAppData, `Vivaldi\Application\vivaldi.exe`),
+		filepath.Join(localAppData, `Chromium\Application\chrome.exe`),
+	}
+}
@@ -0,0 +1,115 @@
+package launcher
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// UsageStat records how often and how recently an app has been launched.
+type UsageStat struct {
+	Count        int       `json:"count"`
+	LastLaunched time.Time `json:"last_launched"`
+}
+
+// UsageCache maps App.Key() to its launch history, persisted at
+// UsageCachePath so frecency ranking survives restarts.
+type UsageCache map[string]UsageStat
+
+const usageHalfLife = 7 * 24 * time.Hour
+
+// UsageCachePath returns %LOCALAPPDATA%\zap\usage.json.
+func UsageCachePath() string {
+	return filepath.Join(os.Getenv("LOCALAPPDATA"), "zap", "usage.json")
+}
+
+// LoadUsageCache reads the on-disk usage cache, returning an empty cache if
+// it doesn't exist or can't be parsed.
+func LoadUsageCache() UsageCache {
+	uc := UsageCache{}
+	data, err := os.ReadFile(UsageCachePath())
+	if err != nil {
+		return uc
+	}
+	_ = json.Unmarshal(data, &uc)
+	return uc
+}
+
+// Save writes the cache to disk, creating its parent directory if needed.
+func (uc UsageCache) Save() error {
+	path := UsageCachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(uc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// RecordLaunch increments the count and stamps the last-launched time for
+// key, then persists the cache synchronously — callers such as the TUI quit
+// right after a launch, so a detached save goroutine would routinely get
+// killed by process exit before it reached disk.
+func (uc UsageCache) RecordLaunch(key string) error {
+	if key == "" {
+		return nil
+	}
+	stat := uc[key]
+	stat.Count++
+	stat.LastLaunched = time.Now()
+	uc[key] = stat
+	return uc.Save()
+}
+
+// frecency scores a usage stat by recency-decayed launch count: each
+// halfLife period since the last launch halves the contribution of the
+// accumulated count, so apps launched often but long ago fade below apps
+// launched a few times recently.
+func frecency(stat UsageStat, now time.Time) float64 {
+	if stat.Count == 0 {
+		return 0
+	}
+	elapsed := now.Sub(stat.LastLaunched)
+	decay := math.Pow(0.5, elapsed.Hours()/usageHalfLife.Hours())
+	return float64(stat.Count) * decay
+}
+
+// RankByFrecency splits apps into the top-n most frecently used (descending
+// score) and the remainder, alphabetical. Apps with no usage history are
+// never included in the top list.
+func RankByFrecency(apps []App, uc UsageCache, n int) (top, rest []App) {
+	now := time.Now()
+	type scored struct {
+		app   App
+		score float64
+	}
+	var candidates []scored
+	for _, app := range apps {
+		if s := frecency(uc[app.Key()], now); s > 0 {
+			candidates = append(candidates, scored{app, s})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	topKeys := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		top = append(top, c.app)
+		topKeys[c.app.Key()] = true
+	}
+	for _, app := range apps {
+		if !topKeys[app.Key()] {
+			rest = append(rest, app)
+		}
+	}
+	return top, rest
+}
@@ -3,16 +3,22 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"net/url"
 	"os"
-	"os/exec"
-	"sort"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/AlexandrosLiaskos/zap/internal/launcher"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 )
 
@@ -24,17 +30,12 @@ var (
 	normalStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("7"))
 	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 	searchStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("5")).Bold(true)
+	matchStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Bold(true)
 	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Italic(true)
+	findBarStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	findHitStyle  = lipgloss.NewStyle().Background(lipgloss.Color("3")).Foreground(lipgloss.Color("0"))
 )
 
-// ── Data ────────────────────────────────────────────────────────
-
-type appEntry struct {
-	Name  string
-	AppID string // shell:AppsFolder ID (from Get-StartApps)
-	Path  string // install location (from registry)
-}
-
 // ── TUI State ───────────────────────────────────────────────────
 
 type viewMode int
@@ -45,19 +46,39 @@ const (
 )
 
 type model struct {
-	input    textinput.Model
-	allApps  []appEntry
-	filtered []appEntry
-	cursor   int
-	offset   int
-	maxShow  int
-	width    int
-	height   int
-	mode     viewMode
-	launched string
-	quitting bool
+	input        textinput.Model
+	allApps      []launcher.App
+	filtered     []launcher.App
+	matches      [][]int      // matched rune indices into filtered[i].Name, parallel to filtered; nil entry means no highlight
+	dividers     map[int]bool // index into filtered that is a non-selectable "── all apps ──" row
+	usage        launcher.UsageCache
+	cursor       int
+	offset       int
+	maxShow      int
+	width        int
+	height       int
+	mode         viewMode
+	launched     string
+	quitting     bool
+	loading      bool
+	spinner      spinner.Model
+	reloadCh     chan []launcher.App
+	searchConfig launcher.SearchConfig
+
+	// In-list incremental search ("find"), distinct from the top-level
+	// filter: it highlights matches within the full m.filtered list rather
+	// than removing non-matches.
+	findMode       bool
+	findTyping     bool // findInput has focus and is still being edited
+	findInput      textinput.Model
+	searchMatches  []int // indices into m.filtered, in list order
+	searchMatchIdx int   // position within searchMatches the cursor is on
 }
 
+// topFrecentShown is how many of the most frecently launched apps are
+// pinned above the "── all apps ──" divider when the query is empty.
+const topFrecentShown = 5
+
 func initialModel() model {
 	ti := textinput.New()
 	ti.Placeholder = "search apps · / to search web · esc to quit"
@@ -65,92 +86,180 @@ func initialModel() model {
 	ti.CharLimit = 200
 	ti.Width = 60
 
-	apps := loadApps()
+	usage := launcher.LoadUsageCache()
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(dimStyle))
 
 	return model{
-		input:    ti,
-		allApps:  apps,
-		filtered: apps,
-		maxShow:  15,
-	}
-}
-
-// ── Load Apps ───────────────────────────────────────────────────
-
-// ghostApps lists display names (lowercase) of apps that have been uninstalled
-// but still appear in Get-StartApps due to Windows caching.
-var ghostApps = map[string]bool{
-	"google chrome": true,
-}
-
-// loadApps collects apps from two sources:
-//  1. Start menu entries via Get-StartApps (PowerShell)
-//  2. Registry uninstall entries with an InstallLocation
-//
-// Ghost entries are excluded. Results are sorted alphabetically.
-func loadApps() []appEntry {
-	seen := make(map[string]bool)
-	var apps []appEntry
-
-	// Start menu apps
-	out, err := exec.Command("powershell", "-NoProfile", "-Command",
-		`Get-StartApps | ForEach-Object { "$($_.Name)|$($_.AppID)" }`).Output()
-	if err == nil {
-		for _, line := range strings.Split(string(out), "\n") {
-			parts := strings.SplitN(strings.TrimSpace(line), "|", 2)
-			if len(parts) != 2 || parts[0] == "" {
-				continue
+		input:        ti,
+		usage:        usage,
+		maxShow:      15,
+		loading:      true,
+		spinner:      sp,
+		searchConfig: launcher.LoadSearchConfig(),
+	}
+}
+
+// rankedAppRows builds the row list shown for an empty query: the top
+// frecently-used apps, a divider, then the remaining apps alphabetically. If no
+// app has usage history, it's just the alphabetical list with no divider.
+func rankedAppRows(apps []launcher.App, usage launcher.UsageCache) ([]launcher.App, map[int]bool) {
+	top, rest := launcher.RankByFrecency(apps, usage, topFrecentShown)
+	if len(top) == 0 {
+		return apps, nil
+	}
+	rows := make([]launcher.App, 0, len(top)+1+len(rest))
+	rows = append(rows, top...)
+	dividerIdx := len(rows)
+	rows = append(rows, launcher.App{Name: "── all apps ──"})
+	rows = append(rows, rest...)
+	return rows, map[int]bool{dividerIdx: true}
+}
+
+// ── App Watcher ─────────────────────────────────────────────────
+
+// reloadDebounce coalesces bursts of registry/filesystem change
+// notifications (e.g. an installer touching dozens of keys) into a single
+// reload.
+const reloadDebounce = 300 * time.Millisecond
+
+// startWatcher watches the registry uninstall keys and the Start Menu
+// Programs folders for changes and re-runs launcher.Load whenever they fire,
+// delivering results on the returned channel. The channel is buffered by
+// one and always holds the most recent reload, so a slow consumer never
+// blocks the watcher.
+func startWatcher() chan []launcher.App {
+	out := make(chan []launcher.App, 1)
+	changed := make(chan struct{}, 1)
+
+	go watchRegistryUninstallKeys(changed)
+	go watchStartMenus(changed)
+	go debounceReloads(changed, out)
+
+	return out
+}
+
+func debounceReloads(changed <-chan struct{}, out chan []launcher.App) {
+	for range changed {
+		time.Sleep(reloadDebounce)
+		for drained := true; drained; {
+			select {
+			case <-changed:
+			default:
+				drained = false
 			}
-			lower := strings.ToLower(parts[0])
-			if seen[lower] || ghostApps[lower] {
-				continue
+		}
+
+		apps := launcher.Load()
+		select {
+		case out <- apps:
+		default:
+			select {
+			case <-out:
+			default:
 			}
-			seen[lower] = true
-			apps = append(apps, appEntry{Name: parts[0], AppID: parts[1]})
+			out <- apps
 		}
 	}
+}
 
-	// Registry apps (uninstall entries with exe paths)
-	regPaths := []string{
+// watchRegistryUninstallKeys blocks on RegNotifyChangeKeyValue for each
+// uninstall key and reports a change whenever a subkey is added, removed,
+// or renamed (i.e. an app was installed or uninstalled).
+func watchRegistryUninstallKeys(changed chan<- struct{}) {
+	paths := []string{
 		`SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`,
 		`SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall`,
 	}
-	for _, rp := range regPaths {
-		k, err := registry.OpenKey(registry.LOCAL_MACHINE, rp, registry.READ)
-		if err != nil {
-			continue
+	for _, rp := range paths {
+		go watchRegistryKey(rp, changed)
+	}
+}
+
+func watchRegistryKey(path string, changed chan<- struct{}) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.NOTIFY|registry.READ)
+	if err != nil {
+		return
+	}
+	defer k.Close()
+
+	for {
+		const filter = windows.REG_NOTIFY_CHANGE_NAME | windows.REG_NOTIFY_CHANGE_LAST_SET
+		if err := windows.RegNotifyChangeKeyValue(windows.Handle(k), true, filter, 0, false); err != nil {
+			return
+		}
+		select {
+		case changed <- struct{}{}:
+		default:
 		}
-		names, _ := k.ReadSubKeyNames(-1)
-		k.Close()
-		for _, name := range names {
-			sub, err := registry.OpenKey(registry.LOCAL_MACHINE, rp+`\`+name, registry.READ)
-			if err != nil {
-				continue
+	}
+}
+
+// watchStartMenus watches the per-user and all-users Start Menu Programs
+// folders for new or removed shortcuts.
+func watchStartMenus(changed chan<- struct{}) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return
+	}
+	defer watcher.Close()
+
+	dirs := []string{
+		filepath.Join(os.Getenv("APPDATA"), `Microsoft\Windows\Start Menu\Programs`),
+		filepath.Join(os.Getenv("ProgramData"), `Microsoft\Windows\Start Menu\Programs`),
+	}
+	for _, d := range dirs {
+		_ = watcher.Add(d)
+	}
+
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
 			}
-			displayName, _, _ := sub.GetStringValue("DisplayName")
-			installLoc, _, _ := sub.GetStringValue("InstallLocation")
-			sub.Close()
-			if displayName == "" || installLoc == "" {
-				continue
+			select {
+			case changed <- struct{}{}:
+			default:
 			}
-			lower := strings.ToLower(displayName)
-			if seen[lower] {
-				continue
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
 			}
-			seen[lower] = true
-			apps = append(apps, appEntry{Name: displayName, Path: installLoc})
 		}
 	}
-
-	sort.Slice(apps, func(i, j int) bool {
-		return strings.ToLower(apps[i].Name) < strings.ToLower(apps[j].Name)
-	})
-	return apps
 }
 
 // ── Bubble Tea ──────────────────────────────────────────────────
 
-func (m model) Init() tea.Cmd { return textinput.Blink }
+// appsLoadedMsg carries the result of the initial asynchronous launcher.Load
+// call so the TUI can paint immediately instead of blocking on PowerShell.
+type appsLoadedMsg struct{ apps []launcher.App }
+
+// appsReloadedMsg carries a refreshed app list from the background
+// registry/Start-menu watcher.
+type appsReloadedMsg struct{ apps []launcher.App }
+
+func loadAppsCmd() tea.Cmd {
+	return func() tea.Msg { return appsLoadedMsg{apps: launcher.Load()} }
+}
+
+// listenForChanges waits on the watcher channel and turns the next reload
+// into a message; Update re-issues this command after each message so
+// listening continues for the lifetime of the program.
+func listenForChanges(ch chan []launcher.App) tea.Cmd {
+	return func() tea.Msg {
+		apps, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return appsReloadedMsg{apps: apps}
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, m.spinner.Tick, loadAppsCmd())
+}
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -160,70 +269,256 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.maxShow = max(m.height-6, 5)
 		return m, nil
 
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case appsLoadedMsg:
+		m.allApps = msg.apps
+		m.loading = false
+		m.filtered, m.dividers = rankedAppRows(m.allApps, m.usage)
+		m.reloadCh = startWatcher()
+		return m, listenForChanges(m.reloadCh)
+
+	case appsReloadedMsg:
+		m.allApps = msg.apps
+		m.applyFilter(m.input.Value())
+		return m, listenForChanges(m.reloadCh)
+
 	case tea.KeyMsg:
 		switch msg.Type {
-		case tea.KeyEsc, tea.KeyCtrlC:
+		case tea.KeyCtrlC:
 			m.quitting = true
 			return m, tea.Quit
+		case tea.KeyEsc:
+			if m.findMode {
+				m.exitFindMode()
+				return m, nil
+			}
+			m.quitting = true
+			return m, tea.Quit
+		case tea.KeyCtrlF:
+			if m.mode == modeApps && !m.findMode {
+				m.enterFindMode()
+				return m, nil
+			}
 		case tea.KeyEnter:
+			if m.findMode && m.findTyping {
+				m.commitFind()
+				return m, nil
+			}
 			return m.handleEnter()
 		case tea.KeyUp:
-			if m.cursor > 0 {
-				m.cursor--
+			if c := m.prevSelectable(m.cursor); c != m.cursor {
+				m.cursor = c
 				if m.cursor < m.offset {
 					m.offset = m.cursor
 				}
 			}
 			return m, nil
 		case tea.KeyDown:
-			if m.cursor < len(m.filtered)-1 {
-				m.cursor++
+			if c := m.nextSelectable(m.cursor); c != m.cursor {
+				m.cursor = c
 				if m.cursor >= m.offset+m.maxShow {
 					m.offset = m.cursor - m.maxShow + 1
 				}
 			}
 			return m, nil
+		case tea.KeyRunes:
+			if m.mode == modeApps && !m.findMode && m.input.Value() == "" && string(msg.Runes) == "?" {
+				m.enterFindMode()
+				return m, nil
+			}
+			if m.findMode && !m.findTyping && len(msg.Runes) == 1 {
+				switch msg.Runes[0] {
+				case 'n':
+					m.jumpMatch(1)
+					return m, nil
+				case 'N':
+					m.jumpMatch(-1)
+					return m, nil
+				}
+			}
 		}
 	}
 
 	var cmd tea.Cmd
+	if m.findTyping {
+		m.findInput, cmd = m.findInput.Update(msg)
+		m.searchMatches = nil
+		for _, idx := range findMatches(m.filtered, m.findInput.Value()) {
+			if !m.dividers[idx] {
+				m.searchMatches = append(m.searchMatches, idx)
+			}
+		}
+		return m, cmd
+	}
+	if m.findMode {
+		// While navigating find matches (not editing the find query), ignore
+		// keys that would otherwise reach the main filter: editing it here
+		// would reshuffle m.filtered out from under m.searchMatches, which
+		// still holds indices into the pre-edit list.
+		return m, nil
+	}
 	m.input, cmd = m.input.Update(msg)
+	m.applyFilter(m.input.Value())
+
+	return m, cmd
+}
+
+// enterFindMode opens the in-list "find" bar for incremental searching
+// within the current (possibly filtered) list, without removing rows.
+func (m *model) enterFindMode() {
+	ti := textinput.New()
+	ti.Placeholder = "find in list… (enter to jump, esc to close)"
+	ti.Width = 40
+	ti.Focus()
+	m.findInput = ti
+	m.findMode = true
+	m.findTyping = true
+	m.searchMatches = nil
+	m.searchMatchIdx = -1
+}
+
+func (m *model) exitFindMode() {
+	m.findInput.Blur()
+	m.findMode = false
+	m.findTyping = false
+	m.searchMatches = nil
+	m.searchMatchIdx = -1
+}
+
+// commitFind stops editing the find query and jumps the cursor to the
+// first match at or after the current cursor position, wrapping to the
+// first match overall if none follow it.
+func (m *model) commitFind() {
+	m.findInput.Blur()
+	m.findTyping = false
+	if len(m.searchMatches) == 0 {
+		return
+	}
+	m.searchMatchIdx = 0
+	for i, idx := range m.searchMatches {
+		if idx >= m.cursor {
+			m.searchMatchIdx = i
+			break
+		}
+	}
+	m.jumpToCurrentMatch()
+}
+
+// jumpMatch moves the cursor to the next (delta=1) or previous (delta=-1)
+// match, wrapping at either end.
+func (m *model) jumpMatch(delta int) {
+	if len(m.searchMatches) == 0 {
+		return
+	}
+	n := len(m.searchMatches)
+	m.searchMatchIdx = ((m.searchMatchIdx+delta)%n + n) % n
+	m.jumpToCurrentMatch()
+}
+
+func (m *model) jumpToCurrentMatch() {
+	if m.searchMatchIdx < 0 || m.searchMatchIdx >= len(m.searchMatches) {
+		return
+	}
+	m.cursor = m.searchMatches[m.searchMatchIdx]
+	m.ensureVisible(m.cursor)
+}
 
-	query := m.input.Value()
+// ensureVisible adjusts m.offset so row i is within the visible window.
+func (m *model) ensureVisible(i int) {
+	if i < m.offset {
+		m.offset = i
+	} else if i >= m.offset+m.maxShow {
+		m.offset = i - m.maxShow + 1
+	}
+}
+
+// findMatches returns the indices into apps whose Name contains query
+// (case-insensitive), in list order.
+func findMatches(apps []launcher.App, query string) []int {
+	if query == "" {
+		return nil
+	}
+	q := strings.ToLower(query)
+	var out []int
+	for i, app := range apps {
+		if strings.Contains(strings.ToLower(app.Name), q) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// applyFilter re-derives m.mode, m.filtered, m.matches and m.dividers from
+// query against m.allApps, resetting the cursor/offset to stay in range.
+func (m *model) applyFilter(query string) {
 	if strings.HasPrefix(query, "/") {
 		m.mode = modeSearch
+		return
+	}
+	m.mode = modeApps
+	if query == "" {
+		m.filtered, m.dividers = rankedAppRows(m.allApps, m.usage)
+		m.matches = nil
 	} else {
-		m.mode = modeApps
-		m.filtered = filterApps(m.allApps, query)
-		if m.cursor >= len(m.filtered) {
-			m.cursor = max(len(m.filtered)-1, 0)
+		m.filtered, m.matches = launcher.Filter(m.allApps, query)
+		m.dividers = nil
+	}
+	if m.cursor >= len(m.filtered) {
+		m.cursor = max(len(m.filtered)-1, 0)
+	}
+	m.cursor = m.nextSelectable(m.cursor - 1)
+	m.offset = 0
+}
+
+// prevSelectable returns the nearest selectable row before from, skipping
+// divider rows, or from itself if there is none.
+func (m model) prevSelectable(from int) int {
+	for i := from - 1; i >= 0; i-- {
+		if !m.dividers[i] {
+			return i
 		}
-		m.offset = 0
 	}
+	return from
+}
 
-	return m, cmd
+// nextSelectable returns the nearest selectable row after from, skipping
+// divider rows, or from itself if there is none.
+func (m model) nextSelectable(from int) int {
+	for i := from + 1; i < len(m.filtered); i++ {
+		if !m.dividers[i] {
+			return i
+		}
+	}
+	return from
 }
 
 func (m model) handleEnter() (tea.Model, tea.Cmd) {
 	if m.mode == modeSearch {
-		query := strings.TrimSpace(strings.TrimPrefix(m.input.Value(), "/"))
-		if query != "" {
-			searchURL := "https://duckduckgo.com/?q=" + url.QueryEscape(query)
-			chromium := os.Getenv("LOCALAPPDATA") + `\Chromium\Application\chrome.exe`
-			_ = exec.Command(chromium, searchURL).Start()
-			m.launched = "Searching: " + query
+		engineURL, terms := m.searchConfig.ResolveSearch(m.input.Value())
+		if terms != "" && engineURL != "" {
+			searchURL := strings.Replace(engineURL, "{query}", url.QueryEscape(terms), 1)
+			if err := launcher.OpenURL(searchURL); err == nil {
+				m.launched = "Searching: " + terms
+			}
 		}
 		m.quitting = true
 		return m, tea.Quit
 	}
 
-	if len(m.filtered) > 0 && m.cursor < len(m.filtered) {
+	if len(m.filtered) > 0 && m.cursor < len(m.filtered) && !m.dividers[m.cursor] {
 		app := m.filtered[m.cursor]
 		m.launched = app.Name
-		if app.AppID != "" {
-			_ = exec.Command("explorer.exe", "shell:AppsFolder\\"+app.AppID).Start()
-		} else if app.Path != "" {
-			_ = exec.Command("explorer.exe", app.Path).Start()
+		if err := launcher.Launch(app); err == nil {
+			if err := m.usage.RecordLaunch(app.Key()); err != nil {
+				fmt.Fprintf(os.Stderr, "zap: %v\n", err)
+			}
 		}
 		m.quitting = true
 		return m, tea.Quit
@@ -241,25 +536,56 @@ func (m model) View() string {
 
 	var b strings.Builder
 	b.WriteString(titleStyle.Render("⚡ zap") + "\n")
+
+	if m.loading {
+		b.WriteString("\n  " + m.spinner.View() + " loading apps…\n")
+		return b.String()
+	}
+
 	b.WriteString("  " + m.input.View() + "\n\n")
 
 	if m.mode == modeSearch {
-		query := strings.TrimSpace(strings.TrimPrefix(m.input.Value(), "/"))
-		b.WriteString(searchStyle.Render("  🔍 Search: ") + normalStyle.Render(query) + "\n")
-		b.WriteString(dimStyle.Render("  enter to search DuckDuckGo") + "\n")
+		_, terms := m.searchConfig.ResolveSearch(m.input.Value())
+		b.WriteString(searchStyle.Render("  🔍 Search: ") + normalStyle.Render(terms) + "\n")
+		b.WriteString(dimStyle.Render("  enter to search · /g google · /b bing · /k kagi") + "\n")
 		return b.String()
 	}
 
+	if m.findMode {
+		b.WriteString(findBarStyle.Render("  find: ") + m.findInput.View() + "\n\n")
+	}
+
+	var matchSet map[int]bool
+	if m.findMode && len(m.searchMatches) > 0 {
+		matchSet = make(map[int]bool, len(m.searchMatches))
+		for _, idx := range m.searchMatches {
+			matchSet[idx] = true
+		}
+	}
+
 	end := min(m.offset+m.maxShow, len(m.filtered))
 	if m.offset > 0 {
 		b.WriteString(dimStyle.Render("  ↑ more") + "\n")
 	}
 	for i := m.offset; i < end; i++ {
+		if m.dividers[i] {
+			b.WriteString(dimStyle.Render("  "+m.filtered[i].Name) + "\n")
+			continue
+		}
+		var matched []int
+		if i < len(m.matches) {
+			matched = m.matches[i]
+		}
+		base := normalStyle
+		prefix := "    "
 		if i == m.cursor {
-			b.WriteString(selectedStyle.Render("  ▸ "+m.filtered[i].Name) + "\n")
-		} else {
-			b.WriteString(normalStyle.Render("    "+m.filtered[i].Name) + "\n")
+			base = selectedStyle
+			prefix = "  ▸ "
+		}
+		if matchSet[i] {
+			base = findHitStyle
 		}
+		b.WriteString(prefix + renderName(m.filtered[i].Name, matched, base) + "\n")
 	}
 	if end < len(m.filtered) {
 		b.WriteString(dimStyle.Render("  ↓ more") + "\n")
@@ -268,33 +594,52 @@ func (m model) View() string {
 		b.WriteString(dimStyle.Render("  no matches") + "\n")
 	}
 
-	b.WriteString("\n" + helpStyle.Render(fmt.Sprintf("  %d apps · ↑↓ navigate · enter launch · / search · esc quit", len(m.filtered))))
+	help := fmt.Sprintf("  %d apps · ↑↓ navigate · enter launch · / search · ctrl+f find · esc quit", len(m.filtered)-len(m.dividers))
+	if m.findMode && !m.findTyping {
+		help = fmt.Sprintf("  %d/%d matches · n/N next/prev · esc close find", m.searchMatchIdx+1, len(m.searchMatches))
+	}
+	b.WriteString("\n" + helpStyle.Render(help))
 	return b.String()
 }
 
 // ── Helpers ─────────────────────────────────────────────────────
 
-func filterApps(apps []appEntry, query string) []appEntry {
-	if query == "" {
-		return apps
+// renderName applies matchStyle to the rune indices in matched and base to
+// everything else, rendering name segment by segment so the two styles can
+// coexist on one line.
+func renderName(name string, matched []int, base lipgloss.Style) string {
+	if len(matched) == 0 {
+		return base.Render(name)
 	}
-	q := strings.ToLower(query)
-	words := strings.Fields(q)
-	var out []appEntry
-	for _, app := range apps {
-		hay := strings.ToLower(app.Name)
-		ok := true
-		for _, w := range words {
-			if !strings.Contains(hay, w) {
-				ok = false
-				break
-			}
+	matchSet := make(map[int]bool, len(matched))
+	for _, idx := range matched {
+		matchSet[idx] = true
+	}
+
+	var b strings.Builder
+	runes := []rune(name)
+	var seg strings.Builder
+	segMatched := matchSet[0]
+	flush := func() {
+		if seg.Len() == 0 {
+			return
 		}
-		if ok {
-			out = append(out, app)
+		if segMatched {
+			b.WriteString(matchStyle.Render(seg.String()))
+		} else {
+			b.WriteString(base.Render(seg.String()))
 		}
+		seg.Reset()
 	}
-	return out
+	for i, r := range runes {
+		if matchSet[i] != segMatched {
+			flush()
+			segMatched = matchSet[i]
+		}
+		seg.WriteRune(r)
+	}
+	flush()
+	return b.String()
 }
 
 func max(a, b int) int {
@@ -313,7 +658,116 @@ func min(a, b int) int {
 
 // ── Entry Point ─────────────────────────────────────────────────
 
+// subcommands dispatches the headless CLI verbs; it returns false if args
+// don't name one, so main can fall through to the TUI.
+func subcommands(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "list":
+		runList(args[1:])
+	case "launch":
+		runLaunch(args[1:])
+	case "search":
+		runSearch(args[1:])
+	case "daemon":
+		runDaemon()
+	default:
+		return false
+	}
+	return true
+}
+
+func runList(args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print apps as a JSON array")
+	fs.Parse(args)
+
+	apps := launcher.Load()
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(apps); err != nil {
+			fmt.Fprintf(os.Stderr, "zap: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	for _, app := range apps {
+		fmt.Println(app.Name)
+	}
+}
+
+func runLaunch(args []string) {
+	fs := flag.NewFlagSet("launch", flag.ExitOnError)
+	first := fs.Bool("first", false, "launch the top match even if the query is ambiguous")
+	fs.Parse(args)
+
+	query := strings.Join(fs.Args(), " ")
+	if query == "" {
+		fmt.Fprintln(os.Stderr, "zap: launch requires a query")
+		os.Exit(1)
+	}
+
+	matches, _ := launcher.Filter(launcher.Load(), query)
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "zap: no app matches %q\n", query)
+		os.Exit(1)
+	}
+	if len(matches) > 1 && !*first {
+		fmt.Fprintf(os.Stderr, "zap: %q is ambiguous (%d matches); pass --first to launch the top match\n", query, len(matches))
+		os.Exit(1)
+	}
+
+	app := matches[0]
+	if err := launcher.Launch(app); err != nil {
+		fmt.Fprintf(os.Stderr, "zap: %v\n", err)
+		os.Exit(1)
+	}
+	if err := launcher.LoadUsageCache().RecordLaunch(app.Key()); err != nil {
+		fmt.Fprintf(os.Stderr, "zap: %v\n", err)
+	}
+	fmt.Println(app.Name)
+}
+
+func runSearch(args []string) {
+	terms := strings.Join(args, " ")
+	if terms == "" {
+		fmt.Fprintln(os.Stderr, "zap: search requires terms")
+		os.Exit(1)
+	}
+	if err := launcher.Search(terms, launcher.LoadSearchConfig()); err != nil {
+		fmt.Fprintf(os.Stderr, "zap: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runDaemon() {
+	d := launcher.NewDaemon(launcher.LoadSearchConfig())
+	fmt.Fprintf(os.Stderr, "zap: listening on %s\n", launcher.PipeName)
+	if err := d.ListenAndServe(); err != nil {
+		fmt.Fprintf(os.Stderr, "zap: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 func main() {
+	if subcommands(os.Args[1:]) {
+		return
+	}
+
+	resetUsage := flag.Bool("reset-usage", false, "wipe the app usage/frecency cache and exit")
+	flag.Parse()
+
+	if *resetUsage {
+		if err := os.Remove(launcher.UsageCachePath()); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "zap: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "zap: %v\n", err)